@@ -2,14 +2,19 @@ package web
 
 import (
 	"bufio"
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"os/exec"
 	pathlib "path"
+	"strings"
 
+	"github.com/antonlindstrom/pgstore"
+	"github.com/boj/redistore"
 	"github.com/go-webpack/webpack"
+	"github.com/go-zepto/zepto/web/middleware"
 	"github.com/go-zepto/zepto/web/renderer"
 	"github.com/go-zepto/zepto/web/renderer/pongo2"
 	"github.com/gorilla/mux"
@@ -23,16 +28,35 @@ type MiddlewareFunc func(RouteHandler) RouteHandler
 
 type App struct {
 	http.Handler
-	opts       Options
-	muxRouter  *mux.Router
-	n          *negroni.Negroni
-	tmplEngine renderer.Engine
-	middleware MiddlewareStack
-	routers    []*Router
+	opts          Options
+	muxRouter     *mux.Router
+	n             *negroni.Negroni
+	tmplEngine    renderer.Engine
+	middleware    MiddlewareStack
+	routers       []*Router
+	webpackCancel context.CancelFunc
+	onStart       []func() error
+	onStop        []func()
 }
 
-func (app *App) startWebpack() {
-	cmd := exec.Command("npm", "run", "start")
+// OnStart registers fn to run during Start, after template/route
+// initialization but before webpack is spawned. Subsystems that need to
+// hook into the App's lifecycle without web importing them back (the
+// plugin supervisor, for one) register here instead.
+func (app *App) OnStart(fn func() error) {
+	app.onStart = append(app.onStart, fn)
+}
+
+// OnStop registers fn to run during Stop, in the reverse order it was
+// registered.
+func (app *App) OnStop(fn func()) {
+	app.onStop = append(app.onStop, fn)
+}
+
+// startWebpack runs the webpack dev server until ctx is cancelled, at which
+// point the process is killed rather than left running in the background.
+func (app *App) startWebpack(ctx context.Context) {
+	cmd := exec.CommandContext(ctx, "npm", "run", "start")
 	stdout, _ := cmd.StdoutPipe()
 	cmd.Stderr = cmd.Stdout
 	scanner := bufio.NewScanner(stdout)
@@ -49,8 +73,12 @@ func (app *App) startWebpack() {
 }
 
 func (app *App) setupSession() {
+	if app.opts.sessionStore != nil {
+		return
+	}
 	env := app.opts.env
-	if app.opts.sessionStore == nil {
+	keyPairs := app.opts.sessionOpts.KeyPairs
+	if len(keyPairs) == 0 {
 		secret := os.Getenv("SESSION_SECRET")
 		if secret == "" {
 			if env == "production" {
@@ -60,7 +88,42 @@ func (app *App) setupSession() {
 				secret = "development-secret"
 			}
 		}
-		app.opts.sessionStore = sessions.NewCookieStore([]byte(secret))
+		keyPairs = [][]byte{[]byte(secret)}
+	}
+	if app.opts.sessionStoreFactory != nil {
+		store, err := app.opts.sessionStoreFactory.New(keyPairs...)
+		if err != nil {
+			app.opts.logger.Fatalf("Failed to initialize session store: %v", err)
+		}
+		app.opts.sessionStore = store
+	} else {
+		app.opts.sessionStore = sessions.NewCookieStore(keyPairs...)
+	}
+	applySessionCookieOptions(app.opts.sessionStore, app.opts.sessionOpts)
+}
+
+// applySessionCookieOptions carries SessionOptions' cookie attributes onto
+// store, for every backend session.Store can build (cookie, filesystem,
+// redis, sql) — they all expose the same *sessions.Options field, just on
+// different concrete types.
+func applySessionCookieOptions(store sessions.Store, so SessionOptions) {
+	cookieOpts := &sessions.Options{
+		MaxAge:   so.MaxAge,
+		Secure:   so.Secure,
+		HttpOnly: so.HttpOnly,
+		SameSite: so.SameSite,
+		Domain:   so.Domain,
+		Path:     so.Path,
+	}
+	switch s := store.(type) {
+	case *sessions.CookieStore:
+		s.Options = cookieOpts
+	case *sessions.FilesystemStore:
+		s.Options = cookieOpts
+	case *redistore.RediStore:
+		s.Options = cookieOpts
+	case *pgstore.PGStore:
+		s.Options = cookieOpts
 	}
 }
 
@@ -68,18 +131,40 @@ func NewApp(opts ...Option) *App {
 	options := newOptions(opts...)
 	if options.tmplEngine == nil {
 		// Use pongo2 as default template engine
-		options.tmplEngine = pongo2.NewPongo2Engine(
+		pongoOpts := []pongo2.Option{
 			pongo2.TemplateDir("templates"),
 			pongo2.Ext(".html"),
 			pongo2.AutoReload(options.env == "development"),
-		)
+		}
+		if options.templateFS != nil {
+			pongoOpts = append(pongoOpts, pongo2.FS(options.templateFS))
+		}
+		options.tmplEngine = pongo2.NewPongo2Engine(pongoOpts...)
 	}
 	muxRouter := mux.NewRouter()
 	staticDir := "/public/"
-	// Create the static router
-	muxRouter.
-		PathPrefix(staticDir).
-		Handler(http.StripPrefix(staticDir, http.FileServer(http.Dir("."+staticDir))))
+	if options.staticOptions != nil {
+		// middleware.Static replaces the plain http.FileServer wiring below
+		// with one that supports ETag/Last-Modified revalidation.
+		static := middleware.Static(options.staticOptionsDir, *options.staticOptions)
+		muxRouter.
+			PathPrefix(staticDir).
+			Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				static.ServeHTTP(w, r, http.NotFound)
+			}))
+	} else {
+		// Serve from StaticFS if supplied, or the default disk/embed
+		// fallback otherwise (see static_default.go / static_embed_opt.go).
+		staticFS := options.staticFS
+		if staticFS == nil {
+			staticFS = defaultStaticFS(strings.TrimPrefix(staticDir, "/"))
+		}
+		if staticFS != nil {
+			muxRouter.
+				PathPrefix(staticDir).
+				Handler(http.StripPrefix(staticDir, http.FileServer(staticFS)))
+		}
+	}
 	app := &App{
 		opts:       options,
 		muxRouter:  muxRouter,
@@ -91,6 +176,9 @@ func NewApp(opts ...Option) *App {
 		routers: make([]*Router, 0),
 	}
 	app.setupSession()
+	if options.enableCompression {
+		app.Use(Adapt(middleware.Compress(options.compressionLevel, options.compressionAlgos...)))
+	}
 	return app
 }
 
@@ -108,6 +196,11 @@ func (app *App) Init() {
 
 func (app *App) Start() {
 	app.Init()
+	for _, fn := range app.onStart {
+		if err := fn(); err != nil {
+			app.opts.logger.Errorf("app: OnStart hook failed: %v", err)
+		}
+	}
 	dev := app.opts.env == "development"
 	if app.opts.webpackEnabled {
 		webpack.FsPath = "./public/build"
@@ -115,13 +208,25 @@ func (app *App) Start() {
 		webpack.Verbose = true
 		webpack.Init(dev)
 		if dev {
-			go func() {
-				app.startWebpack()
-			}()
+			ctx, cancel := context.WithCancel(context.Background())
+			app.webpackCancel = cancel
+			go app.startWebpack(ctx)
 		}
 	}
 }
 
+// Stop shuts down anything Start spun up, in the background or via an
+// OnStop hook (the plugin supervisor, for one). Safe to call even if Start
+// never enabled webpack or registered any hooks.
+func (app *App) Stop() {
+	if app.webpackCancel != nil {
+		app.webpackCancel()
+	}
+	for i := len(app.onStop) - 1; i >= 0; i-- {
+		app.onStop[i]()
+	}
+}
+
 func (app *App) getSession(res http.ResponseWriter, req *http.Request) *Session {
 	session, _ := app.opts.sessionStore.Get(req, app.opts.sessionName)
 	return &Session{
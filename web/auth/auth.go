@@ -0,0 +1,73 @@
+// Package auth provides pluggable JWT validation for zepto route groups,
+// mirroring the negroni+jwtmiddleware pattern: attach Middleware to an
+// app.Group and handlers behind it can read the validated token via
+// auth.Claims / auth.User.
+package auth
+
+import (
+	"crypto/rsa"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// TokenValidator validates a raw bearer token string and returns its claims.
+// Implementations are provided for HS256, RS256 and JWKS-backed RS256
+// (e.g. Auth0, Cognito, or any OIDC provider that publishes a JWKS).
+type TokenValidator interface {
+	Validate(tokenString string) (jwt.MapClaims, error)
+}
+
+// HS256Validator validates tokens signed with a shared HMAC secret.
+type HS256Validator struct {
+	secret []byte
+}
+
+// HS256 builds a TokenValidator for tokens signed with a shared secret.
+func HS256(secret []byte) *HS256Validator {
+	return &HS256Validator{secret: secret}
+}
+
+func (v *HS256Validator) Validate(tokenString string) (jwt.MapClaims, error) {
+	token, err := jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("auth: unexpected signing method %v", t.Header["alg"])
+		}
+		return v.secret, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, fmt.Errorf("auth: invalid token")
+	}
+	return claims, nil
+}
+
+// RS256Validator validates tokens signed with an RSA private/public key pair.
+type RS256Validator struct {
+	publicKey *rsa.PublicKey
+}
+
+// RS256 builds a TokenValidator for tokens signed with an RSA key pair.
+func RS256(publicKey *rsa.PublicKey) *RS256Validator {
+	return &RS256Validator{publicKey: publicKey}
+}
+
+func (v *RS256Validator) Validate(tokenString string) (jwt.MapClaims, error) {
+	token, err := jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("auth: unexpected signing method %v", t.Header["alg"])
+		}
+		return v.publicKey, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, fmt.Errorf("auth: invalid token")
+	}
+	return claims, nil
+}
@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+func TestHS256ValidatorRoundTrip(t *testing.T) {
+	secret := []byte("test-secret")
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "user-1"})
+	signed, err := token.SignedString(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err := HS256(secret).Validate(signed)
+	if err != nil {
+		t.Fatalf("Validate() error = %v, want nil", err)
+	}
+	if sub, _ := claims["sub"].(string); sub != "user-1" {
+		t.Errorf("claims[\"sub\"] = %q, want %q", sub, "user-1")
+	}
+}
+
+func TestHS256ValidatorRejectsWrongSecret(t *testing.T) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "user-1"})
+	signed, err := token.SignedString([]byte("correct-secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := HS256([]byte("wrong-secret")).Validate(signed); err == nil {
+		t.Error("Validate() error = nil, want an error for a mismatched secret")
+	}
+}
+
+func TestHS256ValidatorRejectsWrongSigningMethod(t *testing.T) {
+	token := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{"sub": "user-1"})
+	signed, err := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := HS256([]byte("some-secret")).Validate(signed); err == nil {
+		t.Error("Validate() error = nil, want an error for an unexpected signing method")
+	}
+}
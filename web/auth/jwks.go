@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"fmt"
+
+	"github.com/MicahParks/keyfunc"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// JWKSValidator validates RS256 tokens against keys fetched from a JWKS
+// endpoint, refreshing them in the background as key rotation requires.
+type JWKSValidator struct {
+	jwks *keyfunc.JWKS
+}
+
+// JWKS builds a TokenValidator that fetches and caches signing keys from
+// jwksURL, e.g. "https://your-tenant.auth0.com/.well-known/jwks.json".
+func JWKS(jwksURL string) (*JWKSValidator, error) {
+	jwks, err := keyfunc.Get(jwksURL, keyfunc.Options{RefreshUnknownKID: true})
+	if err != nil {
+		return nil, fmt.Errorf("auth: failed to fetch JWKS from %s: %w", jwksURL, err)
+	}
+	return &JWKSValidator{jwks: jwks}, nil
+}
+
+func (v *JWKSValidator) Validate(tokenString string) (jwt.MapClaims, error) {
+	token, err := jwt.Parse(tokenString, v.jwks.Keyfunc)
+	if err != nil {
+		return nil, err
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, fmt.Errorf("auth: invalid token")
+	}
+	return claims, nil
+}
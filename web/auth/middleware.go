@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-zepto/zepto/web"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+type claimsKeyType int
+
+const claimsKey claimsKeyType = 0
+
+// Middleware returns a web.MiddlewareFunc that reads the bearer token from
+// the Authorization header, validates it with validator, and makes the
+// resulting claims available to downstream handlers through Claims/User.
+// Attach it to a group with app.Group("/api", auth.Middleware(validator)).
+func Middleware(validator TokenValidator) web.MiddlewareFunc {
+	return func(next web.RouteHandler) web.RouteHandler {
+		return func(ctx web.Context) error {
+			req := ctx.Req()
+			header := req.Header.Get("Authorization")
+			parts := strings.SplitN(header, " ", 2)
+			if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+				return fmt.Errorf("auth: missing or malformed Authorization header")
+			}
+			claims, err := validator.Validate(parts[1])
+			if err != nil {
+				return fmt.Errorf("auth: %w", err)
+			}
+			reqWithClaims := req.WithContext(context.WithValue(req.Context(), claimsKey, claims))
+			return next(web.WithReq(ctx, reqWithClaims))
+		}
+	}
+}
+
+// Claims returns the JWT claims validated by Middleware for the current
+// request, or nil if the route isn't behind an authenticated Group.
+func Claims(ctx web.Context) jwt.MapClaims {
+	claims, _ := ctx.Req().Context().Value(claimsKey).(jwt.MapClaims)
+	return claims
+}
+
+// User returns the "sub" claim of the token validated by Middleware for the
+// current request, or "" if the route isn't behind an authenticated Group.
+func User(ctx web.Context) string {
+	claims := Claims(ctx)
+	if claims == nil {
+		return ""
+	}
+	sub, _ := claims["sub"].(string)
+	return sub
+}
@@ -0,0 +1,148 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var bindValidate = validator.New()
+
+// Bind decodes the request body or query string into out, dispatching on
+// the request's Content-Type: application/json decodes the body,
+// application/x-www-form-urlencoded and multipart/form-data parse the form,
+// and any other Content-Type (typically a GET with none) binds from the URL
+// query string.
+// Struct fields are matched by their `json` or `form` tag, falling back to
+// the field name. It replaces the manual decode/parse boilerplate handlers
+// used to need for simple request binding.
+func Bind[T any](ctx Context, out *T) error {
+	req := ctx.Req()
+	contentType := req.Header.Get("Content-Type")
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "application/json":
+		defer req.Body.Close()
+		if err := json.NewDecoder(req.Body).Decode(out); err != nil {
+			return fmt.Errorf("web: bind json: %w", err)
+		}
+		return nil
+	case "application/x-www-form-urlencoded", "multipart/form-data":
+		if mediaType == "multipart/form-data" {
+			if err := req.ParseMultipartForm(32 << 20); err != nil {
+				return fmt.Errorf("web: bind form: %w", err)
+			}
+		} else if err := req.ParseForm(); err != nil {
+			return fmt.Errorf("web: bind form: %w", err)
+		}
+		return bindValues(req.Form, out)
+	default:
+		return bindValues(req.URL.Query(), out)
+	}
+}
+
+// Validate runs obj through the shared go-playground/validator instance,
+// honoring `validate:"..."` struct tags, so handlers can replace manual
+// field-by-field checks after Bind with a single call.
+func Validate(obj interface{}) error {
+	return bindValidate.Struct(obj)
+}
+
+func bindValues(values url.Values, out interface{}) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("web: bind: out must be a pointer to a struct")
+	}
+	return bindStruct(values, rv.Elem())
+}
+
+func bindStruct(values url.Values, sv reflect.Value) error {
+	st := sv.Type()
+	for i := 0; i < st.NumField(); i++ {
+		field := st.Field(i)
+		fv := sv.Field(i)
+		if !fv.CanSet() {
+			continue
+		}
+		if fv.Kind() == reflect.Struct {
+			if err := bindStruct(values, fv); err != nil {
+				return err
+			}
+			continue
+		}
+		name := fieldName(field)
+		if name == "-" {
+			continue
+		}
+		raw, ok := values[name]
+		if !ok || len(raw) == 0 {
+			continue
+		}
+		if err := setFieldValue(fv, raw); err != nil {
+			return fmt.Errorf("web: bind: field %s: %w", field.Name, err)
+		}
+	}
+	return nil
+}
+
+func fieldName(field reflect.StructField) string {
+	if tag, ok := field.Tag.Lookup("form"); ok {
+		return strings.Split(tag, ",")[0]
+	}
+	if tag, ok := field.Tag.Lookup("json"); ok {
+		return strings.Split(tag, ",")[0]
+	}
+	return field.Name
+}
+
+func setFieldValue(fv reflect.Value, raw []string) error {
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(raw[0])
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw[0])
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw[0], 10, 64)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw[0], 10, 64)
+		if err != nil {
+			return err
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw[0], 64)
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(n)
+	case reflect.Slice:
+		items := raw
+		if len(raw) == 1 && strings.Contains(raw[0], ",") {
+			items = strings.Split(raw[0], ",")
+		}
+		slice := reflect.MakeSlice(fv.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := setFieldValue(slice.Index(i), []string{item}); err != nil {
+				return err
+			}
+		}
+		fv.Set(slice)
+	default:
+		return fmt.Errorf("unsupported field kind %s", fv.Kind())
+	}
+	return nil
+}
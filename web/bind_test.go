@@ -0,0 +1,85 @@
+package web
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestBindValuesSimpleFields(t *testing.T) {
+	type in struct {
+		Name string `form:"name"`
+		Age  int    `form:"age"`
+	}
+	var out in
+	err := bindValues(url.Values{"name": {"ada"}, "age": {"36"}}, &out)
+	if err != nil {
+		t.Fatalf("bindValues() error = %v", err)
+	}
+	want := in{Name: "ada", Age: 36}
+	if out != want {
+		t.Errorf("bindValues() = %+v, want %+v", out, want)
+	}
+}
+
+func TestBindValuesFallsBackToFieldName(t *testing.T) {
+	type in struct {
+		Email string
+	}
+	var out in
+	if err := bindValues(url.Values{"Email": {"a@example.com"}}, &out); err != nil {
+		t.Fatalf("bindValues() error = %v", err)
+	}
+	if out.Email != "a@example.com" {
+		t.Errorf("out.Email = %q, want %q", out.Email, "a@example.com")
+	}
+}
+
+func TestBindValuesNestedStruct(t *testing.T) {
+	type Address struct {
+		City string `form:"city"`
+	}
+	type in struct {
+		Name    string `form:"name"`
+		Address Address
+	}
+	var out in
+	err := bindValues(url.Values{"name": {"ada"}, "city": {"london"}}, &out)
+	if err != nil {
+		t.Fatalf("bindValues() error = %v", err)
+	}
+	if out.Name != "ada" || out.Address.City != "london" {
+		t.Errorf("bindValues() = %+v, want name=ada, address.city=london", out)
+	}
+}
+
+func TestBindValuesSlice(t *testing.T) {
+	type in struct {
+		Tags []string `form:"tags"`
+	}
+	var out in
+	if err := bindValues(url.Values{"tags": {"a,b,c"}}, &out); err != nil {
+		t.Fatalf("bindValues() error = %v", err)
+	}
+	want := []string{"a", "b", "c"}
+	if !reflect.DeepEqual(out.Tags, want) {
+		t.Errorf("out.Tags = %v, want %v", out.Tags, want)
+	}
+}
+
+func TestBindValuesRejectsNonStructPointer(t *testing.T) {
+	var out string
+	if err := bindValues(url.Values{}, &out); err == nil {
+		t.Error("bindValues() error = nil, want an error for a non-struct pointer")
+	}
+}
+
+func TestBindValuesInvalidIntReturnsError(t *testing.T) {
+	type in struct {
+		Age int `form:"age"`
+	}
+	var out in
+	if err := bindValues(url.Values{"age": {"not-a-number"}}, &out); err == nil {
+		t.Error("bindValues() error = nil, want an error for an unparseable int")
+	}
+}
@@ -0,0 +1,42 @@
+package web
+
+import "net/http"
+
+// overrideContext wraps a Context, overriding just Res()/Req() while
+// delegating everything else (logger, broker, session, template engine,
+// ...) to the Context it embeds.
+type overrideContext struct {
+	Context
+	res http.ResponseWriter
+	req *http.Request
+}
+
+func (c *overrideContext) Res() http.ResponseWriter {
+	if c.res != nil {
+		return c.res
+	}
+	return c.Context.Res()
+}
+
+func (c *overrideContext) Req() *http.Request {
+	if c.req != nil {
+		return c.req
+	}
+	return c.Context.Req()
+}
+
+// WithRes returns a Context identical to ctx except that Res() returns res.
+// Middleware that wraps the ResponseWriter (Adapt, for compression) uses
+// this so downstream handlers write through the wrapped writer instead of
+// the raw one.
+func WithRes(ctx Context, res http.ResponseWriter) Context {
+	return &overrideContext{Context: ctx, res: res}
+}
+
+// WithReq returns a Context identical to ctx except that Req() returns req.
+// Middleware that needs to thread a modified *http.Request (e.g. one
+// carrying new context.Context values, like auth.Middleware) uses this so
+// downstream handlers and Context helpers see the updated request.
+func WithReq(ctx Context, req *http.Request) Context {
+	return &overrideContext{Context: ctx, req: req}
+}
@@ -0,0 +1,58 @@
+package web
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/go-zepto/zepto/web/renderer"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/sessions"
+	"go.uber.org/fx"
+)
+
+// Module is an fx-based alternative to the imperative NewApp(opts...).Start()
+// flow. opts are threaded straight into NewApp, so anything Addr,
+// WithSessionStore, StaticFS, TemplateFS, EnableCompression, etc. can
+// configure imperatively is also available under fx. Module provides *App,
+// *mux.Router, renderer.Engine, sessions.Store and Logger for user
+// fx.Provide constructors (controllers, resources) to depend on, and
+// registers an fx.Hook that runs app.Start()/app.Stop() (so onStart/onStop
+// hooks like plugin.Attach's Supervisor run too) and starts/stops the HTTP
+// server in step with the fx application lifecycle.
+func Module(opts ...Option) fx.Option {
+	return fx.Options(
+		fx.Provide(
+			func() *App { return NewApp(opts...) },
+			func(app *App) *mux.Router { return app.muxRouter },
+			func(app *App) renderer.Engine { return app.tmplEngine },
+			func(app *App) sessions.Store { return app.opts.sessionStore },
+			func(app *App) Logger { return app.opts.logger },
+		),
+		fx.Invoke(registerLifecycle),
+	)
+}
+
+func registerLifecycle(lc fx.Lifecycle, app *App) {
+	server := &http.Server{Addr: app.opts.addr, Handler: app}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			app.Start()
+			ln, err := net.Listen("tcp", server.Addr)
+			if err != nil {
+				return err
+			}
+			go func() {
+				if err := server.Serve(ln); err != nil && err != http.ErrServerClosed {
+					app.opts.logger.Errorf("http server error: %v", err)
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			app.Stop()
+			return server.Shutdown(ctx)
+		},
+	})
+}
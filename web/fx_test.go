@@ -0,0 +1,35 @@
+package web
+
+import (
+	"testing"
+
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+)
+
+func TestModuleThreadsOptionsAndRunsHooks(t *testing.T) {
+	var started, stopped bool
+
+	app := fxtest.New(t,
+		Module(Addr(":0"), Env("test")),
+		fx.Invoke(func(a *App) {
+			if a.opts.addr != ":0" {
+				t.Errorf("app.opts.addr = %q, want %q", a.opts.addr, ":0")
+			}
+			if a.opts.env != "test" {
+				t.Errorf("app.opts.env = %q, want %q", a.opts.env, "test")
+			}
+			a.OnStart(func() error { started = true; return nil })
+			a.OnStop(func() { stopped = true })
+		}),
+	)
+
+	app.RequireStart()
+	if !started {
+		t.Error("app.onStart hooks did not run under the fx lifecycle")
+	}
+	app.RequireStop()
+	if !stopped {
+		t.Error("app.onStop hooks did not run under the fx lifecycle")
+	}
+}
@@ -0,0 +1,147 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	pathlib "path"
+
+	"github.com/gorilla/mux"
+)
+
+// Group is a subrouter mounted at a path prefix with its own middleware
+// chain, so a set of routes can require things (auth, per-group logging,
+// rate limiting, ...) without affecting the rest of the App. Create one
+// with App.Group and register routes on it exactly like on an App.
+type Group struct {
+	app        *App
+	parent     *Group
+	prefix     string
+	muxRouter  *mux.Router
+	middleware MiddlewareStack
+}
+
+// Group returns a new Group mounted at prefix. mws run, in order, before
+// the App's own middleware stack and before the route handler, so a group
+// can be created with e.g. app.Group("/api", auth.Middleware(validator)) to
+// require a valid token on every route registered on it.
+func (app *App) Group(prefix string, mws ...MiddlewareFunc) *Group {
+	g := &Group{
+		app:       app,
+		prefix:    prefix,
+		muxRouter: app.muxRouter.PathPrefix(prefix).Subrouter(),
+		middleware: MiddlewareStack{
+			stack: make([]MiddlewareFunc, 0),
+			skips: nil,
+		},
+	}
+	g.middleware.Use(mws...)
+	return g
+}
+
+// Group returns a new Group nested under g, mounted at prefix relative to
+// g's own prefix. mws run, in order, before g's own middleware and before
+// the route handler, exactly like App.Group's mws run before the App's.
+func (g *Group) Group(prefix string, mws ...MiddlewareFunc) *Group {
+	ng := &Group{
+		app:       g.app,
+		parent:    g,
+		prefix:    pathlib.Join(g.prefix, prefix),
+		muxRouter: g.muxRouter.PathPrefix(prefix).Subrouter(),
+		middleware: MiddlewareStack{
+			stack: make([]MiddlewareFunc, 0),
+			skips: nil,
+		},
+	}
+	ng.middleware.Use(mws...)
+	return ng
+}
+
+// Use appends middleware to the Group's chain. It only applies to routes
+// registered on this Group (and Groups created from it), not the App.
+func (g *Group) Use(mw ...MiddlewareFunc) {
+	g.middleware.Use(mw...)
+}
+
+// chain composes routeHandler with g's own middleware and, recursively, any
+// parent Group's and finally the App's, innermost first.
+func (g *Group) chain(routeHandler RouteHandler) RouteHandler {
+	var inner RouteHandler
+	if g.parent != nil {
+		inner = g.parent.chain(routeHandler)
+	} else {
+		inner = g.app.middleware.handle(routeHandler)
+	}
+	return g.middleware.handle(inner)
+}
+
+func (g *Group) HandleMethod(methods []string, path string, routeHandler RouteHandler) *Group {
+	g.muxRouter.HandleFunc(path, func(res http.ResponseWriter, req *http.Request) {
+		ctx := NewDefaultContext()
+		ctx.logger = g.app.opts.logger
+		ctx.broker = g.app.opts.broker
+		ctx.res = res
+		ctx.req = req
+		ctx.cookies = &Cookies{
+			res: res,
+			req: req,
+		}
+		ctx.session = g.app.getSession(res, req)
+		ctx.tmplEngine = g.app.tmplEngine
+		defer func() {
+			if r := recover(); r != nil {
+				var e error
+				switch t := r.(type) {
+				case error:
+					e = t
+				case string:
+					e = fmt.Errorf(t)
+				default:
+					e = fmt.Errorf(fmt.Sprint(t))
+				}
+				g.app.HandleError(res, req, e)
+			}
+		}()
+		h := g.chain(routeHandler)
+		if err := h(ctx); err != nil {
+			g.app.HandleError(res, req, err)
+		}
+	}).Methods(methods...)
+	return g
+}
+
+func (g *Group) Get(path string, routeHandler RouteHandler) *Group {
+	return g.HandleMethod([]string{"GET"}, path, routeHandler)
+}
+
+func (g *Group) Post(path string, routeHandler RouteHandler) *Group {
+	return g.HandleMethod([]string{"POST"}, path, routeHandler)
+}
+
+func (g *Group) Put(path string, routeHandler RouteHandler) *Group {
+	return g.HandleMethod([]string{"PUT"}, path, routeHandler)
+}
+
+func (g *Group) Delete(path string, routeHandler RouteHandler) *Group {
+	return g.HandleMethod([]string{"DELETE"}, path, routeHandler)
+}
+
+func (g *Group) Patch(path string, routeHandler RouteHandler) *Group {
+	return g.HandleMethod([]string{"PATCH"}, path, routeHandler)
+}
+
+func (g *Group) Any(path string, routeHandler RouteHandler) *Group {
+	return g.HandleMethod([]string{"GET", "POST", "PUT", "DELETE", "PATCH"}, path, routeHandler)
+}
+
+// Resource registers the standard REST routes for resource on the Group,
+// so nested resources inherit the Group's middleware (auth included)
+// exactly like App.Resource does for top-level resources.
+func (g *Group) Resource(path string, resource Resource) *Group {
+	id_path := pathlib.Join(path, "/{id}")
+	g.Get(path, resource.List)
+	g.Get(id_path, resource.Show)
+	g.Post(path, resource.Create)
+	g.Put(id_path, resource.Update)
+	g.Delete(id_path, resource.Destroy)
+	return g
+}
@@ -0,0 +1,87 @@
+// Package middleware ships negroni.Handler middleware (compression, static
+// assets) that composes with Zepto's own MiddlewareFunc chain through
+// web.Adapt, mirroring the negroni-gzip + negroni-static layering apps in
+// this ecosystem already reach for.
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/urfave/negroni"
+)
+
+// Compress negotiates Accept-Encoding against algos (in the priority order
+// given, e.g. Compress(gzip.DefaultCompression, "br", "gzip")) and wraps the
+// response body in the first one the client accepts, falling back to no
+// compression when none match.
+func Compress(level int, algos ...string) negroni.Handler {
+	return negroni.HandlerFunc(func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+		accepted := r.Header.Get("Accept-Encoding")
+		for _, algo := range algos {
+			if !acceptsEncoding(accepted, algo) {
+				continue
+			}
+			switch algo {
+			case "br":
+				bw := brotli.NewWriterLevel(w, level)
+				defer bw.Close()
+				w.Header().Set("Content-Encoding", "br")
+				w.Header().Add("Vary", "Accept-Encoding")
+				next(&compressWriter{ResponseWriter: w, Writer: bw}, r)
+				return
+			case "gzip":
+				gw, _ := gzip.NewWriterLevel(w, level)
+				defer gw.Close()
+				w.Header().Set("Content-Encoding", "gzip")
+				w.Header().Add("Vary", "Accept-Encoding")
+				next(&compressWriter{ResponseWriter: w, Writer: gw}, r)
+				return
+			}
+		}
+		next(w, r)
+	})
+}
+
+// acceptsEncoding reports whether header (an Accept-Encoding value) allows
+// encoding, honoring q-values — "br;q=0, gzip" explicitly forbids brotli
+// even though "br" appears in the header, which a plain substring match
+// would miss.
+func acceptsEncoding(header, encoding string) bool {
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		name := strings.TrimSpace(fields[0])
+		if !strings.EqualFold(name, encoding) {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			v, ok := strings.CutPrefix(param, "q=")
+			if !ok {
+				continue
+			}
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		return q > 0
+	}
+	return false
+}
+
+// compressWriter lets an http.ResponseWriter's Write calls flow through an
+// arbitrary compressing io.Writer while still exposing the underlying
+// Header/WriteHeader.
+type compressWriter struct {
+	http.ResponseWriter
+	Writer io.Writer
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	return w.Writer.Write(b)
+}
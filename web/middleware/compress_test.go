@@ -0,0 +1,23 @@
+package middleware
+
+import "testing"
+
+func TestAcceptsEncoding(t *testing.T) {
+	cases := []struct {
+		header   string
+		encoding string
+		want     bool
+	}{
+		{"gzip, br", "br", true},
+		{"gzip, br", "deflate", false},
+		{"br;q=0, gzip", "br", false},
+		{"br;q=0, gzip", "gzip", true},
+		{"br;q=0.5, gzip;q=0.8", "br", true},
+		{"", "gzip", false},
+	}
+	for _, c := range cases {
+		if got := acceptsEncoding(c.header, c.encoding); got != c.want {
+			t.Errorf("acceptsEncoding(%q, %q) = %v, want %v", c.header, c.encoding, got, c.want)
+		}
+	}
+}
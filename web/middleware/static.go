@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/negroni"
+)
+
+// StaticOptions configures Static.
+type StaticOptions struct {
+	// IndexFile is served for a request that resolves to a directory.
+	// Defaults to "index.html".
+	IndexFile string
+	// Prefix is stripped from the request path before it's resolved
+	// against Dir, e.g. Prefix "/public" + Dir "./public" serves
+	// GET /public/app.js from ./public/app.js.
+	Prefix string
+}
+
+// Static serves files out of dir with ETag and Last-Modified support, so
+// clients can revalidate with If-None-Match / If-Modified-Since instead of
+// re-downloading unchanged assets — something the plain http.FileServer
+// wiring didn't give handlers for free.
+func Static(dir string, opts StaticOptions) negroni.Handler {
+	if opts.IndexFile == "" {
+		opts.IndexFile = "index.html"
+	}
+	return negroni.HandlerFunc(func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			next(w, r)
+			return
+		}
+		reqPath := strings.TrimPrefix(r.URL.Path, opts.Prefix)
+		fullPath := filepath.Join(dir, filepath.Clean("/"+reqPath))
+		info, err := os.Stat(fullPath)
+		if err != nil {
+			next(w, r)
+			return
+		}
+		if info.IsDir() {
+			fullPath = filepath.Join(fullPath, opts.IndexFile)
+			info, err = os.Stat(fullPath)
+			if err != nil {
+				next(w, r)
+				return
+			}
+		}
+		etag := fileETag(info)
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Last-Modified", info.ModTime().UTC().Format(http.TimeFormat))
+		if match := r.Header.Get("If-None-Match"); match != "" && match == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		http.ServeFile(w, r, fullPath)
+	})
+}
+
+func fileETag(info os.FileInfo) string {
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s-%d-%d", info.Name(), info.Size(), info.ModTime().UnixNano())))
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
@@ -0,0 +1,29 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/urfave/negroni"
+)
+
+// Adapt lets a plain negroni.Handler (compression, static assets, anything
+// written against net/http's middleware shape) compose with the rest of a
+// Group or App's MiddlewareFunc chain via Use.
+func Adapt(h negroni.Handler) MiddlewareFunc {
+	return func(next RouteHandler) RouteHandler {
+		return func(ctx Context) error {
+			var handlerErr error
+			h.ServeHTTP(ctx.Res(), ctx.Req(), func(w http.ResponseWriter, r *http.Request) {
+				wrapped := ctx
+				if w != ctx.Res() {
+					wrapped = WithRes(wrapped, w)
+				}
+				if r != ctx.Req() {
+					wrapped = WithReq(wrapped, r)
+				}
+				handlerErr = next(wrapped)
+			})
+			return handlerErr
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package web
+
+import (
+	"io/fs"
+	"net/http"
+
+	"github.com/go-zepto/zepto/web/middleware"
+	"github.com/go-zepto/zepto/web/renderer"
+	"github.com/go-zepto/zepto/web/session"
+	"github.com/gorilla/sessions"
+)
+
+// Logger is the logging interface Zepto calls into for startup, request and
+// error logging. Any *logrus.Logger (or compatible wrapper) satisfies it.
+type Logger interface {
+	Info(args ...interface{})
+	Warn(args ...interface{})
+	Error(args ...interface{})
+	Errorf(format string, args ...interface{})
+	Fatalf(format string, args ...interface{})
+}
+
+// Broker is the pub/sub hub Context uses to publish and subscribe to
+// application events (e.g. to push updates over websockets).
+type Broker interface {
+	Publish(topic string, payload interface{})
+}
+
+// Options holds the configuration NewApp is built from. Build one with
+// NewApp(opts...) rather than constructing it directly.
+type Options struct {
+	env                 string
+	logger              Logger
+	broker              Broker
+	tmplEngine          renderer.Engine
+	sessionStore        sessions.Store
+	sessionStoreFactory session.Store
+	sessionOpts         SessionOptions
+	sessionName         string
+	webpackEnabled      bool
+	staticFS            http.FileSystem
+	templateFS          fs.FS
+	addr                string
+	enableCompression   bool
+	compressionLevel    int
+	compressionAlgos    []string
+	staticOptions       *middleware.StaticOptions
+	staticOptionsDir    string
+}
+
+// SessionOptions configures the cookie written for whichever session Store
+// is in use, and the auth/encryption key pairs sessions are signed (and
+// optionally encrypted) with. Set more than one (authKey, encKey) pair in
+// KeyPairs to rotate keys without invalidating existing sessions: the first
+// pair signs new sessions, every pair is tried when reading one back, same
+// as gorilla's own cookie store.
+type SessionOptions struct {
+	MaxAge   int
+	Secure   bool
+	HttpOnly bool
+	SameSite http.SameSite
+	Domain   string
+	Path     string
+	KeyPairs [][]byte
+}
+
+// Option configures Options. Pass any number of them to NewApp.
+type Option func(*Options)
+
+func newOptions(opts ...Option) Options {
+	options := Options{
+		env:         "development",
+		sessionName: "zepto-session",
+		addr:        ":3000",
+	}
+	for _, o := range opts {
+		o(&options)
+	}
+	return options
+}
+
+// Env sets the running environment ("development" or "production").
+func Env(env string) Option {
+	return func(o *Options) {
+		o.env = env
+	}
+}
+
+// WithLogger sets the Logger used for startup, request and error logging.
+func WithLogger(logger Logger) Option {
+	return func(o *Options) {
+		o.logger = logger
+	}
+}
+
+// WithBroker sets the pub/sub Broker exposed to handlers through Context.
+func WithBroker(broker Broker) Option {
+	return func(o *Options) {
+		o.broker = broker
+	}
+}
+
+// TemplateEngine overrides the default pongo2 renderer.Engine.
+func TemplateEngine(engine renderer.Engine) Option {
+	return func(o *Options) {
+		o.tmplEngine = engine
+	}
+}
+
+// SessionName sets the cookie name used to store the session id.
+func SessionName(name string) Option {
+	return func(o *Options) {
+		o.sessionName = name
+	}
+}
+
+// WebpackEnabled toggles the webpack dev server integration.
+func WebpackEnabled(enabled bool) Option {
+	return func(o *Options) {
+		o.webpackEnabled = enabled
+	}
+}
+
+// Addr sets the "host:port" the HTTP server listens on when the App is
+// started through Module's fx lifecycle. Defaults to ":3000".
+func Addr(addr string) Option {
+	return func(o *Options) {
+		o.addr = addr
+	}
+}
+
+// StaticFS sets the http.FileSystem static assets are served from, in place
+// of the default "./public/" disk directory. Pass http.FS(embedFS) to serve
+// assets embedded with `go:embed public/*` for a single-binary deployment.
+func StaticFS(fsys http.FileSystem) Option {
+	return func(o *Options) {
+		o.staticFS = fsys
+	}
+}
+
+// TemplateFS sets the fs.FS templates are loaded from, in place of the
+// default template engine's disk directory. Pass an embed.FS wired up with
+// `go:embed templates/*` for a single-binary deployment.
+func TemplateFS(fsys fs.FS) Option {
+	return func(o *Options) {
+		o.templateFS = fsys
+	}
+}
+
+// WithSessionStore swaps the default cookie-only session store for one of
+// the web/session backends (RedisStore, FilesystemStore, SQLStore, ...), or
+// a custom session.Store implementation.
+func WithSessionStore(factory session.Store) Option {
+	return func(o *Options) {
+		o.sessionStoreFactory = factory
+	}
+}
+
+// WithSessionOptions sets the cookie attributes and signing/encryption key
+// pairs used by the session store.
+func WithSessionOptions(so SessionOptions) Option {
+	return func(o *Options) {
+		o.sessionOpts = so
+	}
+}
+
+// EnableCompression turns on gzip/brotli response compression, negotiated
+// against each request's Accept-Encoding header. algos are tried in order,
+// e.g. EnableCompression(gzip.DefaultCompression, "br", "gzip").
+func EnableCompression(level int, algos ...string) Option {
+	return func(o *Options) {
+		o.enableCompression = true
+		o.compressionLevel = level
+		o.compressionAlgos = algos
+	}
+}
+
+// WithStaticOptions replaces the plain http.FileServer static asset wiring
+// with middleware.Static, serving dir with ETag/Last-Modified support.
+func WithStaticOptions(dir string, opts middleware.StaticOptions) Option {
+	return func(o *Options) {
+		o.staticOptionsDir = dir
+		o.staticOptions = &opts
+	}
+}
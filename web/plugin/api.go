@@ -0,0 +1,56 @@
+package plugin
+
+import "net/rpc"
+
+// hostBoundAPI runs inside the plugin process and implements PluginAPI by
+// forwarding every call over RPC to the hostAPIServer the Supervisor is
+// serving in the host process.
+type hostBoundAPI struct {
+	client *rpc.Client
+}
+
+func newHostBoundAPI(client *rpc.Client) *hostBoundAPI {
+	return &hostBoundAPI{client: client}
+}
+
+// Ack is the RPC reply value for calls that have nothing to return but a
+// possible error (the error itself travels as Call's own return value).
+type Ack struct{}
+
+type RouteArgs struct {
+	Path        string
+	HandlerName string
+}
+
+func (a *hostBoundAPI) call(method, path, handlerName string) error {
+	var ack Ack
+	return a.client.Call("HostAPI."+method, RouteArgs{Path: path, HandlerName: handlerName}, &ack)
+}
+
+func (a *hostBoundAPI) Get(path, handler string) error    { return a.call("Get", path, handler) }
+func (a *hostBoundAPI) Post(path, handler string) error   { return a.call("Post", path, handler) }
+func (a *hostBoundAPI) Put(path, handler string) error    { return a.call("Put", path, handler) }
+func (a *hostBoundAPI) Delete(path, handler string) error { return a.call("Delete", path, handler) }
+func (a *hostBoundAPI) Patch(path, handler string) error  { return a.call("Patch", path, handler) }
+
+func (a *hostBoundAPI) Use(hooks ...string) error {
+	var ack Ack
+	return a.client.Call("HostAPI.Use", hooks, &ack)
+}
+
+func (a *hostBoundAPI) Resource(path string, resourceName string) error {
+	return a.call("Resource", path, resourceName)
+}
+
+func (a *hostBoundAPI) Hooks() HookRegistry {
+	return a
+}
+
+func (a *hostBoundAPI) OnRequest(name string) error  { return a.hook("OnRequest", name) }
+func (a *hostBoundAPI) OnResponse(name string) error { return a.hook("OnResponse", name) }
+func (a *hostBoundAPI) OnError(name string) error    { return a.hook("OnError", name) }
+
+func (a *hostBoundAPI) hook(method, name string) error {
+	var ack Ack
+	return a.client.Call("HostAPI."+method, name, &ack)
+}
@@ -0,0 +1,17 @@
+package plugin
+
+import "github.com/go-zepto/zepto/web"
+
+// Attach wires a Supervisor for the plugin binaries in dir into app's
+// lifecycle — Supervisor.Start runs as part of app.Start, and
+// Supervisor.Stop as part of app.Stop — so callers don't have to manage
+// the plugin process pool by hand:
+//
+//	plugin.Attach(app, "./plugins", logger)
+//	app.Start()
+func Attach(app *web.App, dir string, logger web.Logger) *Supervisor {
+	sup := NewSupervisor(dir, app, logger)
+	app.OnStart(sup.Start)
+	app.OnStop(sup.Stop)
+	return sup
+}
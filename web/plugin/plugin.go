@@ -0,0 +1,82 @@
+// Package plugin lets Zepto load out-of-process plugins — ordinary Go
+// binaries speaking hashicorp/go-plugin's RPC protocol — that register
+// routes against an App without being linked into it. Because a plugin
+// runs as its own OS process, a crash (or even a deliberate panic) inside
+// one can never take down the host: the Supervisor just restarts it.
+//
+// Only route registration (PluginAPI.Get/Post/Put/Delete/Patch/Resource) is
+// implemented today. Middleware (PluginAPI.Use) and the hook registry
+// (PluginAPI.Hooks) always return a "not implemented yet" error, and there
+// is no API for registering template functions — both are follow-up work,
+// not something a plugin can rely on yet.
+package plugin
+
+// Handshake is the go-plugin handshake both host and plugin binaries must
+// agree on before a connection is established. It is not a secret, just a
+// version/cookie sanity check that the two sides speak the same protocol.
+var Handshake = struct {
+	ProtocolVersion  uint
+	MagicCookieKey   string
+	MagicCookieValue string
+}{
+	ProtocolVersion:  1,
+	MagicCookieKey:   "ZEPTO_PLUGIN",
+	MagicCookieValue: "a17c1e9c-9c7d-4c34-9e7e-3f5b1c9d5e2a",
+}
+
+// Plugin is implemented by a plugin binary's main package and served with
+// Serve(). OnLoad is called once, immediately after the host establishes
+// the RPC connection, so the plugin can register everything it needs
+// against api before any request is routed to it.
+type Plugin interface {
+	// OnLoad registers routes, middleware and hooks against api.
+	OnLoad(api PluginAPI) error
+	// Handle serves a single request previously registered against api
+	// under handlerName (via PluginAPI.Get/Post/... or Resource).
+	Handle(handlerName string, req Request) (Response, error)
+}
+
+// PluginAPI is the host-side surface a Plugin drives over RPC. It mirrors
+// App's own routing API so, from the plugin's own code, it looks just like
+// registering routes on a normal Zepto App.
+type PluginAPI interface {
+	Get(path string, handler string) error
+	Post(path string, handler string) error
+	Put(path string, handler string) error
+	Delete(path string, handler string) error
+	Patch(path string, handler string) error
+	Use(hooks ...string) error
+	Resource(path string, resourceName string) error
+	Hooks() HookRegistry
+}
+
+// HookRegistry lets a plugin observe (and short-circuit) the request
+// lifecycle for routes it doesn't own outright. Not implemented yet: every
+// method returns an error until hook dispatch is wired into the host.
+type HookRegistry interface {
+	OnRequest(name string) error
+	OnResponse(name string) error
+	OnError(name string) error
+}
+
+// Request is the data a plugin receives for a request routed to it. It is a
+// plain value, not a live *http.Request, precisely so a plugin can't hold a
+// reference into the host's memory — it can only read what was copied
+// across the RPC boundary. The host's request goroutine waits for the
+// plugin's reply.
+type Request struct {
+	Method     string
+	Path       string
+	Header     map[string][]string
+	Body       []byte
+	RemoteAddr string
+	Query      map[string][]string
+}
+
+// Response is what a plugin hands back for a Request; the host copies it
+// onto the real http.ResponseWriter.
+type Response struct {
+	StatusCode int
+	Header     map[string][]string
+	Body       []byte
+}
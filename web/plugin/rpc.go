@@ -0,0 +1,86 @@
+package plugin
+
+import (
+	"net/rpc"
+
+	goplugin "github.com/hashicorp/go-plugin"
+)
+
+// RPCPlugin adapts Plugin/PluginAPI to go-plugin's net/rpc transport. Serve
+// constructs one with Impl set inside the plugin binary; the Supervisor
+// constructs a bare one and passes it to goplugin.NewClient. go-plugin
+// calls Server() inside the plugin process and Client() inside the host.
+type RPCPlugin struct {
+	// Impl is set on the plugin binary's side; nil on the host's side.
+	Impl Plugin
+}
+
+func (p *RPCPlugin) Server(b *goplugin.MuxBroker) (interface{}, error) {
+	return &pluginRPCServer{impl: p.Impl, broker: b}, nil
+}
+
+func (p *RPCPlugin) Client(b *goplugin.MuxBroker, c *rpc.Client) (interface{}, error) {
+	return &pluginRPCClient{client: c, broker: b}, nil
+}
+
+// OnLoadArgs points the plugin process at the broker channel the host is
+// listening for PluginAPI calls on, so the plugin can dial back into it.
+type OnLoadArgs struct {
+	APIBrokerID uint32
+}
+
+// HandleArgs carries a routed request from the host to the plugin process
+// that registered handlerName.
+type HandleArgs struct {
+	HandlerName string
+	Request     Request
+}
+
+// pluginRPCServer runs inside the plugin process. It receives OnLoad once
+// at startup and one Handle call per request routed to this plugin.
+type pluginRPCServer struct {
+	impl   Plugin
+	broker *goplugin.MuxBroker
+	api    *hostBoundAPI
+}
+
+func (s *pluginRPCServer) OnLoad(args OnLoadArgs, reply *Ack) error {
+	conn, err := s.broker.Dial(args.APIBrokerID)
+	if err != nil {
+		return err
+	}
+	s.api = newHostBoundAPI(rpc.NewClient(conn))
+	return s.impl.OnLoad(s.api)
+}
+
+func (s *pluginRPCServer) Handle(args HandleArgs, resp *Response) error {
+	res, err := s.impl.Handle(args.HandlerName, args.Request)
+	if err != nil {
+		return err
+	}
+	*resp = res
+	return nil
+}
+
+// pluginRPCClient runs inside the host process; the Supervisor uses it as
+// its local stand-in for the plugin's Plugin implementation.
+type pluginRPCClient struct {
+	client *rpc.Client
+	broker *goplugin.MuxBroker
+}
+
+// Load tells the plugin to run OnLoad, handing it apiBrokerID so it can
+// dial back into the hostAPIServer the Supervisor is serving on that
+// channel.
+func (c *pluginRPCClient) Load(apiBrokerID uint32) error {
+	var ack Ack
+	return c.client.Call("Plugin.OnLoad", OnLoadArgs{APIBrokerID: apiBrokerID}, &ack)
+}
+
+// Handle routes a single Request to the handler the plugin registered as
+// handlerName during OnLoad.
+func (c *pluginRPCClient) Handle(handlerName string, req Request) (Response, error) {
+	var resp Response
+	err := c.client.Call("Plugin.Handle", HandleArgs{HandlerName: handlerName, Request: req}, &resp)
+	return resp, err
+}
@@ -0,0 +1,20 @@
+package plugin
+
+import goplugin "github.com/hashicorp/go-plugin"
+
+// Serve blocks forever, exposing impl to the host process over go-plugin's
+// RPC protocol. A plugin binary's main package should do nothing but:
+//
+//	func main() { plugin.Serve(&myPlugin{}) }
+func Serve(impl Plugin) {
+	goplugin.Serve(&goplugin.ServeConfig{
+		HandshakeConfig: goplugin.HandshakeConfig{
+			ProtocolVersion:  Handshake.ProtocolVersion,
+			MagicCookieKey:   Handshake.MagicCookieKey,
+			MagicCookieValue: Handshake.MagicCookieValue,
+		},
+		Plugins: map[string]goplugin.Plugin{
+			"zepto": &RPCPlugin{Impl: impl},
+		},
+	})
+}
@@ -0,0 +1,299 @@
+package plugin
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/rpc"
+	"os"
+	"os/exec"
+	pathlib "path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-zepto/zepto/web"
+	goplugin "github.com/hashicorp/go-plugin"
+)
+
+// Supervisor loads every executable in Dir as a Zepto plugin, restarting
+// any that crash and refusing to launch anything that isn't actually
+// inside Dir (a plugin path built from user input can't be used to exec an
+// arbitrary binary elsewhere on disk).
+type Supervisor struct {
+	Dir    string
+	App    *web.App
+	Logger web.Logger
+
+	mu        sync.Mutex
+	instances map[string]*pluginInstance
+	stopped   bool
+}
+
+type pluginInstance struct {
+	binary string
+	client *goplugin.Client
+}
+
+// NewSupervisor returns a Supervisor for the plugin binaries in dir.
+func NewSupervisor(dir string, app *web.App, logger web.Logger) *Supervisor {
+	return &Supervisor{
+		Dir:       dir,
+		App:       app,
+		Logger:    logger,
+		instances: make(map[string]*pluginInstance),
+	}
+}
+
+// Start discovers every executable in Supervisor.Dir and loads it, then
+// watches each one and restarts it if it exits unexpectedly. It returns
+// once every discovered plugin has completed OnLoad (or failed to).
+func (s *Supervisor) Start() error {
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		return fmt.Errorf("plugin: reading %s: %w", s.Dir, err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		binary, err := s.resolveBinary(entry.Name())
+		if err != nil {
+			s.Logger.Errorf("plugin: skipping %s: %v", entry.Name(), err)
+			continue
+		}
+		if err := s.launch(binary); err != nil {
+			s.Logger.Errorf("plugin: failed to load %s: %v", binary, err)
+		}
+	}
+	return nil
+}
+
+// resolveBinary rejects anything that would resolve outside Dir, so a
+// symlink or a "../" in a configured plugin name can't be used to run an
+// arbitrary binary elsewhere on the host.
+func (s *Supervisor) resolveBinary(name string) (string, error) {
+	dir, err := filepath.Abs(s.Dir)
+	if err != nil {
+		return "", err
+	}
+	candidate := filepath.Join(dir, name)
+	real, err := filepath.EvalSymlinks(candidate)
+	if err != nil {
+		return "", err
+	}
+	realDir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(realDir, real)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("refuses to escape plugin directory %s", s.Dir)
+	}
+	return candidate, nil
+}
+
+func (s *Supervisor) launch(binary string) error {
+	client := goplugin.NewClient(&goplugin.ClientConfig{
+		HandshakeConfig: goplugin.HandshakeConfig{
+			ProtocolVersion:  Handshake.ProtocolVersion,
+			MagicCookieKey:   Handshake.MagicCookieKey,
+			MagicCookieValue: Handshake.MagicCookieValue,
+		},
+		Plugins: map[string]goplugin.Plugin{
+			"zepto": &RPCPlugin{},
+		},
+		Cmd:    exec.Command(binary),
+		Stderr: io.Discard,
+	})
+
+	rpcClient, err := client.Client()
+	if err != nil {
+		client.Kill()
+		return err
+	}
+	raw, err := rpcClient.Dispense("zepto")
+	if err != nil {
+		client.Kill()
+		return err
+	}
+	pc := raw.(*pluginRPCClient)
+
+	broker := pc.broker
+	apiBrokerID := broker.NextId()
+	rpcServer := rpc.NewServer()
+	if err := rpcServer.RegisterName("HostAPI", &hostAPIServer{app: s.App, client: pc}); err != nil {
+		client.Kill()
+		return err
+	}
+	// Serve rpcServer itself on the channel: AcceptAndServe would register
+	// whatever it is given under the "Plugin" name, not "HostAPI".
+	go func() {
+		conn, err := broker.Accept(apiBrokerID)
+		if err != nil {
+			s.Logger.Errorf("plugin: accepting host API connection for %s: %v", binary, err)
+			return
+		}
+		rpcServer.ServeConn(conn)
+	}()
+
+	if err := pc.Load(apiBrokerID); err != nil {
+		client.Kill()
+		return fmt.Errorf("OnLoad: %w", err)
+	}
+
+	s.mu.Lock()
+	s.instances[binary] = &pluginInstance{binary: binary, client: client}
+	s.mu.Unlock()
+
+	go s.watch(binary)
+	return nil
+}
+
+// watch restarts binary with a short backoff whenever go-plugin reports its
+// process has exited, unless Stop has already been called.
+func (s *Supervisor) watch(binary string) {
+	s.mu.Lock()
+	inst := s.instances[binary]
+	s.mu.Unlock()
+	if inst == nil {
+		return
+	}
+	for !inst.client.Exited() {
+		time.Sleep(500 * time.Millisecond)
+	}
+	s.mu.Lock()
+	stopped := s.stopped
+	s.mu.Unlock()
+	if stopped {
+		return
+	}
+	s.Logger.Warn(fmt.Sprintf("plugin: %s exited, restarting", binary))
+	time.Sleep(time.Second)
+	if err := s.launch(binary); err != nil {
+		s.Logger.Errorf("plugin: failed to restart %s: %v", binary, err)
+	}
+}
+
+// Stop tears down every plugin process cleanly.
+func (s *Supervisor) Stop() {
+	s.mu.Lock()
+	s.stopped = true
+	instances := s.instances
+	s.instances = make(map[string]*pluginInstance)
+	s.mu.Unlock()
+	for _, inst := range instances {
+		inst.client.Kill()
+	}
+}
+
+// hostAPIServer runs in the host process and is what a plugin's
+// hostBoundAPI calls into over RPC to register routes, middleware and
+// hooks against the real App.
+type hostAPIServer struct {
+	app    *web.App
+	client *pluginRPCClient
+}
+
+func (h *hostAPIServer) register(methods []string, args RouteArgs, reply *Ack) error {
+	h.app.HandleMethod(methods, args.Path, func(ctx web.Context) error {
+		return h.dispatch(ctx, args.HandlerName)
+	})
+	return nil
+}
+
+func (h *hostAPIServer) Get(args RouteArgs, reply *Ack) error {
+	return h.register([]string{"GET"}, args, reply)
+}
+
+func (h *hostAPIServer) Post(args RouteArgs, reply *Ack) error {
+	return h.register([]string{"POST"}, args, reply)
+}
+
+func (h *hostAPIServer) Put(args RouteArgs, reply *Ack) error {
+	return h.register([]string{"PUT"}, args, reply)
+}
+
+func (h *hostAPIServer) Delete(args RouteArgs, reply *Ack) error {
+	return h.register([]string{"DELETE"}, args, reply)
+}
+
+func (h *hostAPIServer) Patch(args RouteArgs, reply *Ack) error {
+	return h.register([]string{"PATCH"}, args, reply)
+}
+
+// Resource registers the standard REST routes for args.HandlerName on the
+// host App, exactly like App.Resource does for a resource compiled into the
+// host — except each action is dispatched back over RPC to the plugin as
+// "<HandlerName>.<Action>" instead of calling a Go method directly.
+func (h *hostAPIServer) Resource(args RouteArgs, reply *Ack) error {
+	idPath := pathlib.Join(args.Path, "/{id}")
+	actions := []struct {
+		methods []string
+		path    string
+		action  string
+	}{
+		{[]string{"GET"}, args.Path, "List"},
+		{[]string{"GET"}, idPath, "Show"},
+		{[]string{"POST"}, args.Path, "Create"},
+		{[]string{"PUT"}, idPath, "Update"},
+		{[]string{"DELETE"}, idPath, "Destroy"},
+	}
+	for _, a := range actions {
+		if err := h.register(a.methods, RouteArgs{Path: a.path, HandlerName: args.HandlerName + "." + a.action}, reply); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Use and the HookRegistry methods below have no host-side implementation
+// yet: hook dispatch isn't wired into the request lifecycle, so a plugin
+// that calls them gets a real error back instead of a fabricated success.
+func (h *hostAPIServer) Use(hooks []string, reply *Ack) error {
+	return fmt.Errorf("plugin: Use is not implemented yet")
+}
+
+func (h *hostAPIServer) OnRequest(name string, reply *Ack) error {
+	return fmt.Errorf("plugin: OnRequest is not implemented yet")
+}
+
+func (h *hostAPIServer) OnResponse(name string, reply *Ack) error {
+	return fmt.Errorf("plugin: OnResponse is not implemented yet")
+}
+
+func (h *hostAPIServer) OnError(name string, reply *Ack) error {
+	return fmt.Errorf("plugin: OnError is not implemented yet")
+}
+
+// dispatch turns a live request into the plain Request/Response pair a
+// plugin process can safely handle without ever touching the host's
+// *http.Request or http.ResponseWriter.
+func (h *hostAPIServer) dispatch(ctx web.Context, handlerName string) error {
+	req := ctx.Req()
+	body, _ := io.ReadAll(req.Body)
+	resp, err := h.client.Handle(handlerName, Request{
+		Method:     req.Method,
+		Path:       req.URL.Path,
+		Header:     map[string][]string(req.Header),
+		Body:       body,
+		RemoteAddr: req.RemoteAddr,
+		Query:      map[string][]string(req.URL.Query()),
+	})
+	if err != nil {
+		return fmt.Errorf("plugin: handler %s: %w", handlerName, err)
+	}
+	res := ctx.Res()
+	for k, values := range resp.Header {
+		for _, v := range values {
+			res.Header().Add(k, v)
+		}
+	}
+	if resp.StatusCode == 0 {
+		resp.StatusCode = http.StatusOK
+	}
+	res.WriteHeader(resp.StatusCode)
+	_, err = res.Write(resp.Body)
+	return err
+}
@@ -0,0 +1,34 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveBinaryRejectsEscape(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "good"), []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	outside := t.TempDir()
+	secret := filepath.Join(outside, "secret")
+	if err := os.WriteFile(secret, []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(secret, filepath.Join(dir, "evil")); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Supervisor{Dir: dir}
+
+	if _, err := s.resolveBinary("good"); err != nil {
+		t.Errorf("resolveBinary(%q) = %v, want nil error", "good", err)
+	}
+	if _, err := s.resolveBinary("evil"); err == nil {
+		t.Errorf("resolveBinary(%q) = nil error, want an escape error", "evil")
+	}
+	if _, err := s.resolveBinary("../secret"); err == nil {
+		t.Errorf("resolveBinary(%q) = nil error, want an escape error", "../secret")
+	}
+}
@@ -0,0 +1,51 @@
+// Package pongo2 adapts flosch/pongo2 to Zepto's renderer.Engine interface.
+package pongo2
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/flosch/pongo2/v6"
+	"github.com/go-zepto/zepto/web/renderer"
+)
+
+// Pongo2Engine renders templates with flosch/pongo2. Build one with
+// NewPongo2Engine and pass it to web.TemplateEngine, or leave it as
+// NewApp's default.
+type Pongo2Engine struct {
+	opts Options
+	set  *pongo2.TemplateSet
+}
+
+// NewPongo2Engine builds a Pongo2Engine. Without a FS Option, it falls back
+// to reading opts.templateDir straight off disk by default, and requires a
+// FS Option when built with -tags embed (see fs_default.go / fs_embed_opt.go).
+func NewPongo2Engine(opts ...Option) *Pongo2Engine {
+	return &Pongo2Engine{
+		opts: newOptions(opts...),
+	}
+}
+
+var _ renderer.Engine = (*Pongo2Engine)(nil)
+
+func (e *Pongo2Engine) Init() error {
+	fsys := e.opts.fs
+	if fsys == nil {
+		fsys = defaultFS(e.opts.templateDir)
+	}
+	if fsys == nil {
+		return fmt.Errorf("pongo2: no templates filesystem configured; pass pongo2.FS(embedFS), or build without the \"embed\" tag to read templates from disk")
+	}
+	loader := pongo2.NewFSLoader(fsys)
+	e.set = pongo2.NewSet("zepto", loader)
+	e.set.Debug = e.opts.autoReload
+	return nil
+}
+
+func (e *Pongo2Engine) Render(w io.Writer, name string, data map[string]interface{}) error {
+	tmpl, err := e.set.FromCache(name + e.opts.ext)
+	if err != nil {
+		return fmt.Errorf("pongo2: %w", err)
+	}
+	return tmpl.ExecuteWriter(pongo2.Context(data), w)
+}
@@ -0,0 +1,16 @@
+//go:build !embed
+
+package pongo2
+
+import (
+	"io/fs"
+	"os"
+)
+
+// defaultFS backs templates straight off disk, re-readable on every
+// AutoReload render. This is the unconditional default so an app renders
+// out of the box without any extra build tags; pass -tags embed to build a
+// single binary that requires a FS Option instead (see fs_embed_opt.go).
+func defaultFS(templateDir string) fs.FS {
+	return os.DirFS(templateDir)
+}
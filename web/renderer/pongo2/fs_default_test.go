@@ -0,0 +1,11 @@
+//go:build !embed
+
+package pongo2
+
+import "testing"
+
+func TestDefaultFSReadsFromDisk(t *testing.T) {
+	if fsys := defaultFS("."); fsys == nil {
+		t.Fatal("defaultFS(...) = nil, want a disk-backed fs.FS when built without the \"embed\" tag")
+	}
+}
@@ -0,0 +1,12 @@
+//go:build embed
+
+package pongo2
+
+import "io/fs"
+
+// defaultFS has no disk to fall back to in an "embed" build: callers must
+// supply their own embed.FS via the FS Option (e.g. `go:embed templates/*`)
+// for a single-binary deployment.
+func defaultFS(templateDir string) fs.FS {
+	return nil
+}
@@ -0,0 +1,58 @@
+package pongo2
+
+import "io/fs"
+
+// Options configures a Pongo2Engine.
+type Options struct {
+	templateDir string
+	ext         string
+	autoReload  bool
+	fs          fs.FS
+}
+
+// Option configures Options. Pass any number of them to NewPongo2Engine.
+type Option func(*Options)
+
+func newOptions(opts ...Option) Options {
+	options := Options{
+		templateDir: "templates",
+		ext:         ".html",
+	}
+	for _, o := range opts {
+		o(&options)
+	}
+	return options
+}
+
+// TemplateDir sets the directory templates are loaded from when no FS is
+// supplied and the binary wasn't built with the "embed" build tag.
+func TemplateDir(dir string) Option {
+	return func(o *Options) {
+		o.templateDir = dir
+	}
+}
+
+// Ext sets the file extension appended when a template is rendered by name.
+func Ext(ext string) Option {
+	return func(o *Options) {
+		o.ext = ext
+	}
+}
+
+// AutoReload re-parses templates from disk on every render instead of
+// compiling each one on its first render and caching it; only meaningful for the disk-backed default (not the
+// "embed" build), since an embedded filesystem never changes at runtime.
+func AutoReload(enabled bool) Option {
+	return func(o *Options) {
+		o.autoReload = enabled
+	}
+}
+
+// FS supplies the filesystem templates are loaded from, typically an
+// embed.FS wired up with `go:embed templates/*` by the application. When
+// set, it takes precedence over TemplateDir on every build.
+func FS(fsys fs.FS) Option {
+	return func(o *Options) {
+		o.fs = fsys
+	}
+}
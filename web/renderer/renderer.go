@@ -0,0 +1,22 @@
+// Package renderer defines the template rendering contract Zepto's App
+// delegates to, so the concrete engine (pongo2, html/template, ...) can be
+// swapped via Options without changing app.go.
+package renderer
+
+import (
+	"io"
+	"net/http"
+)
+
+// Engine renders named templates with a data context. Init is called once
+// during App.Init, before the first request is served.
+type Engine interface {
+	Init() error
+	Render(w io.Writer, name string, data map[string]interface{}) error
+}
+
+// RenderDevelopmentError writes a developer-friendly error page for err,
+// used by App.HandleError when running in the "development" environment.
+func RenderDevelopmentError(w http.ResponseWriter, r *http.Request, err error) {
+	io.WriteString(w, "<h1>Internal Server Error</h1><pre>"+err.Error()+"</pre>")
+}
@@ -0,0 +1,18 @@
+package session
+
+import "github.com/gorilla/sessions"
+
+type filesystemStore struct {
+	path string
+}
+
+// FilesystemStore builds a Store that writes each session to its own file
+// under path, useful for local development or single-instance deployments
+// that don't want to run Redis.
+func FilesystemStore(path string) Store {
+	return &filesystemStore{path: path}
+}
+
+func (s *filesystemStore) New(keyPairs ...[]byte) (sessions.Store, error) {
+	return sessions.NewFilesystemStore(s.path, keyPairs...), nil
+}
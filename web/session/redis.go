@@ -0,0 +1,29 @@
+package session
+
+import (
+	"fmt"
+
+	"github.com/boj/redistore"
+	"github.com/gorilla/sessions"
+)
+
+type redisStore struct {
+	size     int
+	network  string
+	address  string
+	password string
+}
+
+// RedisStore builds a Store backed by a single Redis instance at address
+// (host:port), using password if the server requires auth.
+func RedisStore(address, password string) Store {
+	return &redisStore{size: 10, network: "tcp", address: address, password: password}
+}
+
+func (s *redisStore) New(keyPairs ...[]byte) (sessions.Store, error) {
+	store, err := redistore.NewRediStore(s.size, s.network, s.address, s.password, keyPairs...)
+	if err != nil {
+		return nil, fmt.Errorf("session: redis: %w", err)
+	}
+	return store, nil
+}
@@ -0,0 +1,15 @@
+// Package session ships gorilla/sessions Store backends so a Zepto app can
+// swap where sessions are persisted (Redis, disk, SQL) through
+// web.WithSessionStore instead of forking the framework.
+package session
+
+import "github.com/gorilla/sessions"
+
+// Store builds a gorilla sessions.Store from one or more auth/encryption
+// key pairs. Pass more than one pair to web.SessionOptions.KeyPairs to
+// rotate keys: the first pair signs/encrypts new sessions, and every pair
+// is tried when reading an existing one, exactly as gorilla's own cookie
+// store behaves.
+type Store interface {
+	New(keyPairs ...[]byte) (sessions.Store, error)
+}
@@ -0,0 +1,28 @@
+package session
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/antonlindstrom/pgstore"
+	"github.com/gorilla/sessions"
+)
+
+type sqlStore struct {
+	db *sql.DB
+}
+
+// SQLStore builds a Store that persists sessions in a Postgres table via
+// the given *sql.DB, reusing the connection pool the rest of the app
+// already has open instead of standing up a separate Redis dependency.
+func SQLStore(db *sql.DB) Store {
+	return &sqlStore{db: db}
+}
+
+func (s *sqlStore) New(keyPairs ...[]byte) (sessions.Store, error) {
+	store, err := pgstore.NewPGStoreFromPool(s.db, keyPairs...)
+	if err != nil {
+		return nil, fmt.Errorf("session: sql: %w", err)
+	}
+	return store, nil
+}
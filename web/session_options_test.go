@@ -0,0 +1,40 @@
+package web
+
+import (
+	"testing"
+
+	"github.com/antonlindstrom/pgstore"
+	"github.com/boj/redistore"
+	"github.com/gorilla/sessions"
+)
+
+func TestApplySessionCookieOptions(t *testing.T) {
+	so := SessionOptions{MaxAge: 3600, Secure: true, HttpOnly: true, Domain: "example.com", Path: "/"}
+
+	cookieStore := sessions.NewCookieStore([]byte("secret"))
+	applySessionCookieOptions(cookieStore, so)
+	assertCookieOptions(t, "CookieStore", cookieStore.Options, so)
+
+	fsStore := sessions.NewFilesystemStore(t.TempDir(), []byte("secret"))
+	applySessionCookieOptions(fsStore, so)
+	assertCookieOptions(t, "FilesystemStore", fsStore.Options, so)
+
+	redisStore := &redistore.RediStore{}
+	applySessionCookieOptions(redisStore, so)
+	assertCookieOptions(t, "RediStore", redisStore.Options, so)
+
+	sqlStore := &pgstore.PGStore{}
+	applySessionCookieOptions(sqlStore, so)
+	assertCookieOptions(t, "PGStore", sqlStore.Options, so)
+}
+
+func assertCookieOptions(t *testing.T, name string, got *sessions.Options, so SessionOptions) {
+	t.Helper()
+	if got == nil {
+		t.Fatalf("%s: Options = nil, want it set", name)
+	}
+	if got.MaxAge != so.MaxAge || got.Secure != so.Secure || got.HttpOnly != so.HttpOnly ||
+		got.Domain != so.Domain || got.Path != so.Path {
+		t.Errorf("%s: Options = %+v, want it to carry SessionOptions %+v", name, got, so)
+	}
+}
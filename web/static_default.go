@@ -0,0 +1,14 @@
+//go:build !embed
+
+package web
+
+import "net/http"
+
+// defaultStaticFS serves assets straight off the "./<dir>" disk directory.
+// This is the unconditional default so an app works out of the box without
+// any extra build tags; pass -tags embed to build a single binary that
+// requires callers to supply their own embedded filesystem via
+// Options.StaticFS instead (see static_embed_opt.go).
+func defaultStaticFS(dir string) http.FileSystem {
+	return http.Dir("./" + dir)
+}
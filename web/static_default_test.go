@@ -0,0 +1,11 @@
+//go:build !embed
+
+package web
+
+import "testing"
+
+func TestDefaultStaticFSServesFromDisk(t *testing.T) {
+	if fs := defaultStaticFS("public"); fs == nil {
+		t.Fatal("defaultStaticFS(...) = nil, want a disk-backed http.FileSystem when built without the \"embed\" tag")
+	}
+}
@@ -0,0 +1,12 @@
+//go:build embed
+
+package web
+
+import "net/http"
+
+// defaultStaticFS has no disk to fall back to in an "embed" build: callers
+// must supply their own embedded filesystem via Options.StaticFS (e.g.
+// `go:embed public/*` plus http.FS(embedFS)) for a single-binary deployment.
+func defaultStaticFS(dir string) http.FileSystem {
+	return nil
+}